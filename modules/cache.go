@@ -1,34 +1,56 @@
 package modules
 
 import (
-	"sync"
+	"io"
 	"time"
+
+	v2 "github.com/rgil90/goin-memory-ds/modules/v2"
 )
 
+// CacheItem is kept for backward compatibility with code that
+// referenced it directly before Cache became a thin wrapper around
+// v2.Cache. It is no longer used internally.
+//
+// Deprecated: this type is unused by Cache; it exists only so existing
+// references to it keep compiling.
 type CacheItem struct {
 	Value      interface{}
 	Expiration int64 // Unix timestamp in seconds for when this item expires (0 means no expiration)
 }
 
+// Cache is the original interface{}-based cache API. It is kept for
+// backward compatibility and is now a thin wrapper around the generic
+// v2.Cache[string, interface{}], so both APIs share the same
+// eviction/expiration internals.
 type Cache struct {
-	mutex          sync.RWMutex
-	items       map[string]CacheItem
-	stopCleanup chan bool
+	inner *v2.Cache[string, interface{}]
 }
 
+// EvictionReason describes why an item left the cache.
+type EvictionReason = v2.EvictionReason
+
+// Eviction reasons passed to an OnEvicted callback.
+const (
+	ReasonExpired  = v2.ReasonExpired
+	ReasonDeleted  = v2.ReasonDeleted
+	ReasonCleared  = v2.ReasonCleared
+	ReasonReplaced = v2.ReasonReplaced
+	ReasonCapacity = v2.ReasonCapacity
+)
+
 /*
-This function creates a new cache with automatic cleanup.
+This function creates a new cache with automatic cleanup. Options such
+as WithCapacity and WithPolicy can be used to bound its size.
 */
-func NewCache() *Cache {
-	cache := &Cache{
-		items:       make(map[string]CacheItem),
-		stopCleanup: make(chan bool),
+func NewCache(opts ...Option) *Cache {
+	v2Opts := make([]v2.Option[string, interface{}], len(opts))
+	for i, opt := range opts {
+		v2Opts[i] = v2.Option[string, interface{}](opt)
+	}
+
+	return &Cache{
+		inner: v2.NewCache[string, interface{}](v2Opts...),
 	}
-	
-	// Start the background cleanup
-	go cache.startCleanup()
-	
-	return cache
 }
 
 /*
@@ -40,127 +62,91 @@ Parameters:
 - ttl: The time-to-live for the item. If not provided, the item will not expire.
 */
 func (c *Cache) Set(key string, value interface{}, ttl ...time.Duration) {
-	var expiration int64 = 0 // Default: no expiration
-	
-	if len(ttl) > 0 && ttl[0] > 0 {
-		expiration = time.Now().Add(ttl[0]).Unix()
-	}
-
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	
-	c.items[key] = CacheItem{
-		Value:      value,
-		Expiration: expiration,
-	}
+	c.inner.Set(key, value, ttl...)
 }
 
 /*
 This function retrieves a value from the cache, returning nil if expired or not found.
-If the item is found and not expired, the item's TTL is extended to the new TTL.
 
 Parameters:
 - key: The key of the item to retrieve.
-- value: The value to set in the cache.
-- ttl: The time-to-live for the item. If not provided, the item will not expire.
 
 Returns:
 - The value of the item if found and not expired.
 - False if the item is not found or expired.
 */
 func (c *Cache) Get(key string) (interface{}, bool) {
-	now := time.Now().Unix()
-
-	c.mutex.RLock()
-	item, found := c.items[key]
-	c.mutex.RUnlock()
-	
-	if !found {
-		return nil, false
-	}
-	
-	// Check if the item has expired
-	if item.Expiration > 0 && now >= item.Expiration {
-		c.Delete(key)
-		return nil, false
-	}
-	
-	return item.Value, true
+	return c.inner.Get(key)
 }
 
 /*
 This function deletes an item from the cache.
 */
 func (c *Cache) Delete(key string) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	delete(c.items, key)
+	c.inner.Delete(key)
 }
 
 /*
 This function clears all items from the cache.
 */
 func (c *Cache) Clear() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.items = make(map[string]CacheItem)
+	c.inner.Clear()
 }
 
 func (c *Cache) Keys() []string {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-	
-	keys := make([]string, 0, len(c.items))
-	for key := range c.items {
-		keys = append(keys, key)
-	}
-	return keys
+	return c.inner.Keys()
 }
 
-/*
-This function starts a ticker that removes expired items every second.
-*/
-func (c *Cache) startCleanup() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-	
-	for {
-		select {
-		case <-ticker.C:
-			c.deleteExpired()
-		case <-c.stopCleanup:
-			return
-		}
-	}
+// Shut down the cache cleanup goroutine
+func (c *Cache) Close() {
+	c.inner.Close()
 }
 
-/*
-This function removes all expired items from the cache in two phases.
-First, it identifies the expired keys.
-Then, it deletes the expired keys from the cache.
-This is done in two phases to avoid modifying the map during iteration.
-*/
-func (c *Cache) deleteExpired() {
-	now := time.Now().Unix()
-	
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	
-	var expiredKeys []string
-	
-	// First, identify expired keys
-	for key, item := range c.items {
-		if item.Expiration > 0 && now >= item.Expiration {
-			expiredKeys = append(expiredKeys, key)
-		}
-	}
-	
-	for _, key := range expiredKeys {
-		delete(c.items, key)
-	}
+// SetLoaderFunc configures the function used to populate the cache on
+// a GetOrLoad miss. ttl of 0 means the loaded value will not expire.
+func (c *Cache) SetLoaderFunc(fn func(key string) (interface{}, time.Duration, error)) {
+	c.inner.SetLoaderFunc(fn)
 }
 
-// Shut down the cache cleanup goroutine
-func (c *Cache) Close() {
-	close(c.stopCleanup)
+// GetOrLoad returns the cached value for key if present and not
+// expired. Otherwise it invokes the configured loader exactly once,
+// even if multiple goroutines call GetOrLoad for the same missing key
+// concurrently, and caches the result with the TTL the loader returns.
+func (c *Cache) GetOrLoad(key string) (interface{}, error) {
+	return c.inner.GetOrLoad(key)
+}
+
+// OnEvicted registers fn to be called whenever an item leaves the
+// cache, whether through expiration, Delete, Clear, a replacing Set, or
+// capacity-limited eviction.
+func (c *Cache) OnEvicted(fn func(key string, value interface{}, reason EvictionReason)) {
+	c.inner.OnEvicted(fn)
+}
+
+// Save serializes the cache's current contents to w using
+// encoding/gob, preserving keys, values, and remaining TTLs. Since
+// values are interface{}, callers must gob.Register their concrete
+// value types before calling Save or Load.
+func (c *Cache) Save(w io.Writer) error {
+	return c.inner.Save(w)
+}
+
+// Load replaces the cache's contents with a snapshot previously
+// written by Save. Entries whose TTL has already elapsed are dropped
+// instead of being loaded.
+func (c *Cache) Load(r io.Reader) error {
+	return c.inner.Load(r)
+}
+
+// SaveFile serializes the cache to the file at path, creating it if
+// necessary and truncating it otherwise. This lets a service restart
+// with a warm cache rather than cold-starting.
+func (c *Cache) SaveFile(path string) error {
+	return c.inner.SaveFile(path)
+}
+
+// LoadFile replaces the cache's contents with a snapshot read from the
+// file at path.
+func (c *Cache) LoadFile(path string) error {
+	return c.inner.LoadFile(path)
 }
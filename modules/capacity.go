@@ -0,0 +1,37 @@
+package modules
+
+import v2 "github.com/rgil90/goin-memory-ds/modules/v2"
+
+// Policy selects which item to evict when a capacity-limited cache is
+// full and a new key needs to be inserted.
+type Policy = v2.Policy
+
+// Eviction policies usable with WithPolicy.
+const (
+	PolicyLRU  = v2.PolicyLRU
+	PolicyLFU  = v2.PolicyLFU
+	PolicyFIFO = v2.PolicyFIFO
+)
+
+// Option configures a Cache at construction time, for use with NewCache.
+type Option func(*v2.Cache[string, interface{}])
+
+// WithCapacity limits the cache to at most max items. When a Set would
+// exceed that limit, an item is evicted first according to the
+// configured Policy (PolicyLRU by default).
+func WithCapacity(max int) Option {
+	return Option(v2.WithCapacity[string, interface{}](max))
+}
+
+// WithPolicy selects the eviction policy used once WithCapacity is set.
+func WithPolicy(policy Policy) Option {
+	return Option(v2.WithPolicy[string, interface{}](policy))
+}
+
+// SkipTTLExtensionOnHit decouples LRU/LFU touch tracking from TTL
+// renewal. By default, a capacity-limited cache renews an item's TTL
+// whenever it is touched by Get; passing true keeps the touch tracking
+// without renewing the TTL, for DNS-style fixed expiration despite reads.
+func SkipTTLExtensionOnHit(skip bool) Option {
+	return Option(v2.SkipTTLExtensionOnHit[string, interface{}](skip))
+}
@@ -0,0 +1,23 @@
+package modules
+
+import "testing"
+
+/*
+This function tests that NewCache honors WithCapacity and WithPolicy,
+evicting the oldest key once the limit is exceeded under PolicyFIFO.
+*/
+func TestCacheWithCapacityAndPolicy(t *testing.T) {
+	cache := NewCache(WithCapacity(2), WithPolicy(PolicyFIFO))
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+
+	if _, found := cache.Get("a"); found {
+		t.Error("Expected 'a' to be evicted once capacity was exceeded")
+	}
+	if len(cache.Keys()) != 2 {
+		t.Errorf("Expected 2 keys after capacity eviction, got %d", len(cache.Keys()))
+	}
+}
@@ -0,0 +1,79 @@
+package modules
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+This function tests that OnEvicted fires with the correct reason for
+expiration, deletion, clearing, and replacement.
+*/
+func TestCacheOnEvicted(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	var mu sync.Mutex
+	reasons := make(map[string]EvictionReason)
+
+	cache.OnEvicted(func(key string, value interface{}, reason EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		reasons[key] = reason
+	})
+
+	cache.Set("deleted", "v")
+	cache.Delete("deleted")
+
+	cache.Set("cleared", "v")
+	cache.Clear()
+
+	cache.Set("replaced", "v1")
+	cache.Set("replaced", "v2")
+
+	cache.Set("expires", "v", time.Second)
+	time.Sleep(2 * time.Second)
+	cache.Get("expires") // trigger expiration on access
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if reasons["deleted"] != ReasonDeleted {
+		t.Errorf("Expected ReasonDeleted for 'deleted', got %v", reasons["deleted"])
+	}
+	if reasons["cleared"] != ReasonCleared {
+		t.Errorf("Expected ReasonCleared for 'cleared', got %v", reasons["cleared"])
+	}
+	if reasons["replaced"] != ReasonReplaced {
+		t.Errorf("Expected ReasonReplaced for 'replaced', got %v", reasons["replaced"])
+	}
+	if reasons["expires"] != ReasonExpired {
+		t.Errorf("Expected ReasonExpired for 'expires', got %v", reasons["expires"])
+	}
+}
+
+/*
+This function tests that OnEvicted callbacks also fire from the
+background cleanup goroutine, not just on-access expiration.
+*/
+func TestCacheOnEvictedFromBackgroundCleanup(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	fired := make(chan EvictionReason, 1)
+	cache.OnEvicted(func(key string, value interface{}, reason EvictionReason) {
+		fired <- reason
+	})
+
+	cache.Set("bg-expires", "v", time.Second)
+
+	select {
+	case reason := <-fired:
+		if reason != ReasonExpired {
+			t.Errorf("Expected ReasonExpired, got %v", reason)
+		}
+	case <-time.After(3 * time.Second):
+		t.Error("Expected OnEvicted to fire from background cleanup")
+	}
+}
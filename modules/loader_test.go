@@ -0,0 +1,169 @@
+package modules
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+This function tests that GetOrLoad invokes the configured loader on a
+miss and caches the result so a subsequent call is served from cache.
+*/
+func TestCacheGetOrLoad(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	var calls int32
+	cache.SetLoaderFunc(func(key string) (interface{}, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		return "loaded-" + key, 0, nil
+	})
+
+	value, err := cache.GetOrLoad("a")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if value != "loaded-a" {
+		t.Errorf("Expected 'loaded-a', got %v", value)
+	}
+
+	value, err = cache.GetOrLoad("a")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if value != "loaded-a" {
+		t.Errorf("Expected cached 'loaded-a', got %v", value)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected loader to run exactly once, ran %d times", calls)
+	}
+}
+
+/*
+This function fires many goroutines at the same missing key and
+asserts the loader only runs once, with every caller receiving the
+same result.
+*/
+func TestCacheGetOrLoadSingleFlight(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	var calls int32
+	cache.SetLoaderFunc(func(key string) (interface{}, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return "value", 0, nil
+	})
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	results := make([]interface{}, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(idx int) {
+			defer wg.Done()
+			value, err := cache.GetOrLoad("shared-key")
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			results[idx] = value
+		}(i)
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected loader to run exactly once, ran %d times", calls)
+	}
+	for i, value := range results {
+		if value != "value" {
+			t.Errorf("Goroutine %d expected 'value', got %v", i, value)
+		}
+	}
+}
+
+/*
+This function guards against a prior bug where inflight waiters were
+unblocked before the loaded value was written to the cache, leaving a
+window where a fresh caller found neither an inflight entry nor a
+cached value and kicked off a second, independent load. It hammers
+GetOrLoad right around when the original call's loader is expected to
+finish, when that window would have been open.
+*/
+func TestCacheGetOrLoadSingleFlightAcrossCacheWrite(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	var calls int32
+	cache.SetLoaderFunc(func(key string) (interface{}, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return "value", 0, nil
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cache.GetOrLoad("k")
+	}()
+
+	time.Sleep(15 * time.Millisecond)
+	for i := 0; i < 50; i++ {
+		if _, err := cache.GetOrLoad("k"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected loader to run exactly once, ran %d times", calls)
+	}
+}
+
+/*
+This function tests that GetOrLoad returns an error when no loader has
+been configured.
+*/
+func TestCacheGetOrLoadNoLoaderConfigured(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	_, err := cache.GetOrLoad("missing")
+	if err == nil {
+		t.Error("Expected an error when no loader function is configured")
+	}
+}
+
+/*
+This function tests that a loader-supplied TTL is honored, expiring the
+loaded value like any other Set call.
+*/
+func TestCacheGetOrLoadRespectsTTL(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	cache.SetLoaderFunc(func(key string) (interface{}, time.Duration, error) {
+		return fmt.Sprintf("value-%s", key), time.Second, nil
+	})
+
+	value, err := cache.GetOrLoad("k")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if value != "value-k" {
+		t.Errorf("Expected 'value-k', got %v", value)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	_, found := cache.Get("k")
+	if found {
+		t.Error("Expected loaded value to expire according to loader TTL")
+	}
+}
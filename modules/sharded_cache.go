@@ -0,0 +1,79 @@
+package modules
+
+import (
+	"hash/fnv"
+	"runtime"
+	"time"
+)
+
+// ShardedCache fans keys out across a fixed number of independent Cache
+// shards, each with its own mutex, cleanup goroutine, and item map, to
+// avoid a single mutex becoming a bottleneck under many concurrent
+// writers.
+type ShardedCache struct {
+	shards []*Cache
+}
+
+// NewShardedCache creates a ShardedCache with the given number of
+// shards. If shards is less than 1, runtime.GOMAXPROCS(0) is used.
+func NewShardedCache(shards int) *ShardedCache {
+	if shards < 1 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	sc := &ShardedCache{
+		shards: make([]*Cache, shards),
+	}
+
+	for i := range sc.shards {
+		sc.shards[i] = NewCache()
+	}
+
+	return sc
+}
+
+// shardFor selects the shard responsible for key using fnv-1a, a fast
+// non-cryptographic hash.
+func (sc *ShardedCache) shardFor(key string) *Cache {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return sc.shards[h.Sum32()%uint32(len(sc.shards))]
+}
+
+// Set adds a value to the cache with an optional TTL.
+func (sc *ShardedCache) Set(key string, value interface{}, ttl ...time.Duration) {
+	sc.shardFor(key).Set(key, value, ttl...)
+}
+
+// Get retrieves a value from the cache, returning nil if expired or not found.
+func (sc *ShardedCache) Get(key string) (interface{}, bool) {
+	return sc.shardFor(key).Get(key)
+}
+
+// Delete deletes an item from the cache.
+func (sc *ShardedCache) Delete(key string) {
+	sc.shardFor(key).Delete(key)
+}
+
+// Keys returns all keys across every shard.
+func (sc *ShardedCache) Keys() []string {
+	var keys []string
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Clear clears all items from every shard.
+func (sc *ShardedCache) Clear() {
+	for _, shard := range sc.shards {
+		shard.Clear()
+	}
+}
+
+// Close shuts down the cleanup goroutine on every shard.
+func (sc *ShardedCache) Close() {
+	for _, shard := range sc.shards {
+		shard.Close()
+	}
+}
@@ -0,0 +1,146 @@
+package modules
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+/*
+This function tests the basic functionality of setting values and
+retrieving them from a sharded cache with four shards, verifying that
+a key is found again on the shard it was stored in.
+*/
+func TestShardedCacheSetAndGet(t *testing.T) {
+	cache := NewShardedCache(4)
+	defer cache.Close()
+
+	cache.Set("name", "GoCache")
+	value, found := cache.Get("name")
+	if !found {
+		t.Error("Expected to find key 'name'")
+	}
+	if value != "GoCache" {
+		t.Errorf("Expected value 'GoCache', got %v", value)
+	}
+
+	_, found = cache.Get("missing")
+	if found {
+		t.Error("Expected not to find key 'missing'")
+	}
+}
+
+/*
+This function tests that Delete, Keys, and Clear fan out correctly
+across every shard.
+*/
+func TestShardedCacheKeysDeleteClear(t *testing.T) {
+	cache := NewShardedCache(4)
+	defer cache.Close()
+
+	for i := 0; i < 50; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), i)
+	}
+
+	if len(cache.Keys()) != 50 {
+		t.Errorf("Expected 50 keys, got %d", len(cache.Keys()))
+	}
+
+	cache.Delete("key0")
+	if _, found := cache.Get("key0"); found {
+		t.Error("Expected key0 to be deleted")
+	}
+
+	cache.Clear()
+	if len(cache.Keys()) != 0 {
+		t.Errorf("Expected 0 keys after clear, got %d", len(cache.Keys()))
+	}
+}
+
+/*
+This function tests that a ShardedCache created with shards < 1 falls
+back to runtime.GOMAXPROCS(0) shards.
+*/
+func TestNewShardedCacheDefaultShardCount(t *testing.T) {
+	cache := NewShardedCache(0)
+	defer cache.Close()
+
+	if len(cache.shards) < 1 {
+		t.Errorf("Expected at least one shard, got %d", len(cache.shards))
+	}
+}
+
+/*
+This function exercises the sharded cache with many concurrent
+goroutines writing and reading distinct keys, similar in shape to
+TestCacheConcurrentAccess for the single-mutex Cache.
+*/
+func TestShardedCacheConcurrentAccess(t *testing.T) {
+	cache := NewShardedCache(8)
+	defer cache.Close()
+
+	const goroutines = 10
+	const operationsPerGoroutine = 100
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines * 2)
+
+	for i := 0; i < goroutines; i++ {
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < operationsPerGoroutine; j++ {
+				key := fmt.Sprintf("key_%d_%d", id, j)
+				cache.Set(key, j)
+			}
+		}(i)
+	}
+
+	for i := 0; i < goroutines; i++ {
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < operationsPerGoroutine; j++ {
+				key := fmt.Sprintf("key_%d_%d", id, j)
+				cache.Get(key)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	expectedItems := goroutines * operationsPerGoroutine
+	if len(cache.Keys()) != expectedItems {
+		t.Errorf("Expected %d items in cache, got %d", expectedItems, len(cache.Keys()))
+	}
+}
+
+/*
+These benchmarks compare a single-mutex Cache against a ShardedCache
+under concurrent writers, demonstrating the throughput improvement
+fanning out across shards provides.
+*/
+func BenchmarkCacheConcurrentSet(b *testing.B) {
+	cache := NewCache()
+	defer cache.Close()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Set(fmt.Sprintf("key%d", i), i)
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedCacheConcurrentSet(b *testing.B) {
+	cache := NewShardedCache(runtime.GOMAXPROCS(0))
+	defer cache.Close()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Set(fmt.Sprintf("key%d", i), i)
+			i++
+		}
+	})
+}
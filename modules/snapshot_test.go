@@ -0,0 +1,43 @@
+package modules
+
+import (
+	"encoding/gob"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	// Since Cache values are interface{}, gob requires every concrete
+	// type stored in the cache to be registered before Save/Load.
+	gob.Register("")
+	gob.Register(float64(0))
+}
+
+/*
+This function tests that SaveFile and LoadFile round-trip the cache's
+contents through a file on disk.
+*/
+func TestCacheSaveLoadFile(t *testing.T) {
+	cache := NewCache()
+	defer cache.Close()
+
+	cache.Set("name", "GoCache")
+	cache.Set("version", 1.0)
+
+	path := filepath.Join(t.TempDir(), "cache.gob")
+	if err := cache.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	restored := NewCache()
+	defer restored.Close()
+
+	if err := restored.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	value, found := restored.Get("name")
+	if !found || value != "GoCache" {
+		t.Errorf("Expected 'name' to restore to 'GoCache', got %v, found=%v", value, found)
+	}
+}
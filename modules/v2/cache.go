@@ -0,0 +1,435 @@
+package v2
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// item holds a single cache entry. Expiration is a Unix timestamp in
+// seconds for when the item expires (0 means no expiration). ttl is
+// the original duration passed to Set, kept so a capacity-limited
+// cache can renew expiration on touch.
+type item[V any] struct {
+	value      V
+	expiration int64
+	ttl        time.Duration
+}
+
+// Cache is a generic, thread-safe, in-memory cache with optional
+// per-item TTLs and a background cleanup goroutine.
+//
+// This is the typed successor to modules.Cache: keys and values are
+// parameterized so callers avoid runtime type assertions. modules.Cache
+// is kept as a thin wrapper around Cache[string, interface{}] so both
+// APIs share the same eviction and expiration internals.
+type Cache[K comparable, V any] struct {
+	mutex       sync.RWMutex
+	items       map[K]item[V]
+	stopCleanup chan bool
+
+	// expHeap/expIndex track only items with a TTL, ordered by
+	// expiration so the cleanup goroutine can sleep until the next
+	// one is actually due instead of polling the whole map.
+	expHeap  expirationHeap[K]
+	expIndex map[K]*expiringItem[K]
+	wakeCh   chan struct{}
+
+	loaderMu sync.Mutex
+	loader   loaderFunc[K, V]
+	inflight map[K]*call[V]
+
+	onEvicted evictionCallback[K, V]
+
+	// capacity, policy and tracker implement WithCapacity/WithPolicy.
+	// tracker is nil unless capacity > 0.
+	capacity              int
+	policy                Policy
+	skipTTLExtensionOnHit bool
+	tracker               capacityTracker[K]
+}
+
+// Option configures a Cache at construction time.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// NewCache creates a new generic cache with automatic cleanup.
+func NewCache[K comparable, V any](opts ...Option[K, V]) *Cache[K, V] {
+	cache := &Cache[K, V]{
+		items:       make(map[K]item[V]),
+		stopCleanup: make(chan bool),
+		expIndex:    make(map[K]*expiringItem[K]),
+		wakeCh:      make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(cache)
+	}
+
+	if cache.capacity > 0 {
+		cache.tracker = newCapacityTracker[K](cache.policy)
+	}
+
+	// Start the background cleanup
+	go cache.startCleanup()
+
+	return cache
+}
+
+// Set adds a value to the cache with an optional TTL.
+//
+// Parameters:
+// - key: The key of the item to set.
+// - value: The value to set in the cache.
+// - ttl: The time-to-live for the item. If not provided, the item will not expire.
+func (c *Cache[K, V]) Set(key K, value V, ttl ...time.Duration) {
+	var expiration int64 = 0 // Default: no expiration
+	var itemTTL time.Duration
+
+	if len(ttl) > 0 && ttl[0] > 0 {
+		itemTTL = ttl[0]
+		expiration = time.Now().Add(itemTTL).Unix()
+	}
+
+	c.mutex.Lock()
+
+	var evicted []evictedEntry[K, V]
+	_, existed := c.items[key]
+	if existed {
+		evicted = append(evicted, evictedEntry[K, V]{key: key, value: c.items[key].value, reason: ReasonReplaced})
+	}
+
+	c.items[key] = item[V]{
+		value:      value,
+		expiration: expiration,
+		ttl:        itemTTL,
+	}
+
+	wake := c.updateExpirationLocked(key, expiration)
+
+	if c.tracker != nil {
+		if existed {
+			c.tracker.touch(key)
+		} else {
+			// Evict before tracking the new key so it is never the
+			// candidate chosen to make room for itself.
+			evicted = append(evicted, c.evictForCapacityLocked()...)
+			c.tracker.add(key)
+		}
+	}
+
+	c.mutex.Unlock()
+
+	if wake {
+		c.wakeCleanup()
+	}
+
+	c.notifyEvicted(evicted)
+}
+
+// evictForCapacityLocked evicts items according to the configured
+// Policy until the cache is back within capacity. The caller must hold
+// c.mutex. Only keys present in c.tracker are candidates, so a key
+// already in items but not yet added to c.tracker is never evicted.
+func (c *Cache[K, V]) evictForCapacityLocked() []evictedEntry[K, V] {
+	var evicted []evictedEntry[K, V]
+
+	for len(c.items) > c.capacity {
+		key, ok := c.tracker.evictCandidate()
+		if !ok {
+			break
+		}
+
+		it, found := c.items[key]
+		if !found {
+			continue
+		}
+
+		delete(c.items, key)
+		if entry, ok := c.expIndex[key]; ok {
+			heap.Remove(&c.expHeap, entry.index)
+			delete(c.expIndex, key)
+		}
+
+		evicted = append(evicted, evictedEntry[K, V]{key: key, value: it.value, reason: ReasonCapacity})
+	}
+
+	return evicted
+}
+
+// updateExpirationLocked keeps the expiration heap in sync with a Set
+// call, pushing a new entry, re-prioritizing an existing one via
+// heap.Fix, or removing it if the key no longer has a TTL. The caller
+// must hold c.mutex. It reports whether the new expiration became the
+// earliest in the heap, meaning the cleanup goroutine's timer should be
+// woken to account for it.
+func (c *Cache[K, V]) updateExpirationLocked(key K, expiration int64) bool {
+	existing, hasEntry := c.expIndex[key]
+
+	if expiration == 0 {
+		if hasEntry {
+			heap.Remove(&c.expHeap, existing.index)
+			delete(c.expIndex, key)
+		}
+		return false
+	}
+
+	if hasEntry {
+		existing.expiresAt = expiration
+		heap.Fix(&c.expHeap, existing.index)
+	} else {
+		entry := &expiringItem[K]{key: key, expiresAt: expiration}
+		heap.Push(&c.expHeap, entry)
+		c.expIndex[key] = entry
+	}
+
+	return c.expHeap[0].key == key
+}
+
+// Get retrieves a value from the cache, returning the zero value of V
+// and false if the key is missing or expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	now := time.Now().Unix()
+
+	c.mutex.RLock()
+	it, found := c.items[key]
+	c.mutex.RUnlock()
+
+	if !found {
+		var zero V
+		return zero, false
+	}
+
+	// Check if the item has expired
+	if it.expiration > 0 && now >= it.expiration {
+		c.mutex.Lock()
+		var evicted []evictedEntry[K, V]
+		if cur, found := c.items[key]; found && cur.expiration > 0 && now >= cur.expiration {
+			evicted = append(evicted, evictedEntry[K, V]{key: key, value: cur.value, reason: ReasonExpired})
+			delete(c.items, key)
+			if entry, ok := c.expIndex[key]; ok {
+				heap.Remove(&c.expHeap, entry.index)
+				delete(c.expIndex, key)
+			}
+			if c.tracker != nil {
+				c.tracker.remove(key)
+			}
+		}
+		c.mutex.Unlock()
+		c.notifyEvicted(evicted)
+
+		var zero V
+		return zero, false
+	}
+
+	if c.tracker != nil {
+		c.mutex.Lock()
+		if cur, found := c.items[key]; found {
+			c.tracker.touch(key)
+
+			if !c.skipTTLExtensionOnHit && cur.ttl > 0 {
+				cur.expiration = time.Now().Add(cur.ttl).Unix()
+				c.items[key] = cur
+				if entry, ok := c.expIndex[key]; ok {
+					entry.expiresAt = cur.expiration
+					heap.Fix(&c.expHeap, entry.index)
+				}
+			}
+
+			it = cur
+		}
+		c.mutex.Unlock()
+	}
+
+	return it.value, true
+}
+
+// GetOrSet returns the existing value for key if present and not expired.
+// Otherwise it stores value with the given ttl and returns it.
+func (c *Cache[K, V]) GetOrSet(key K, value V, ttl ...time.Duration) (V, bool) {
+	if existing, found := c.Get(key); found {
+		return existing, true
+	}
+
+	c.Set(key, value, ttl...)
+	return value, false
+}
+
+// Range calls fn for every non-expired item in the cache. Iteration
+// stops early if fn returns false. fn is called outside the cache lock.
+func (c *Cache[K, V]) Range(fn func(key K, value V) bool) {
+	now := time.Now().Unix()
+
+	c.mutex.RLock()
+	snapshot := make(map[K]item[V], len(c.items))
+	for key, it := range c.items {
+		snapshot[key] = it
+	}
+	c.mutex.RUnlock()
+
+	for key, it := range snapshot {
+		if it.expiration > 0 && now >= it.expiration {
+			continue
+		}
+		if !fn(key, it.value) {
+			return
+		}
+	}
+}
+
+// Delete deletes an item from the cache.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mutex.Lock()
+
+	var evicted []evictedEntry[K, V]
+	if old, found := c.items[key]; found {
+		evicted = append(evicted, evictedEntry[K, V]{key: key, value: old.value, reason: ReasonDeleted})
+	}
+	delete(c.items, key)
+
+	if entry, found := c.expIndex[key]; found {
+		heap.Remove(&c.expHeap, entry.index)
+		delete(c.expIndex, key)
+	}
+
+	if c.tracker != nil {
+		c.tracker.remove(key)
+	}
+
+	c.mutex.Unlock()
+
+	c.notifyEvicted(evicted)
+}
+
+// Clear clears all items from the cache.
+func (c *Cache[K, V]) Clear() {
+	c.mutex.Lock()
+
+	evicted := make([]evictedEntry[K, V], 0, len(c.items))
+	for key, it := range c.items {
+		evicted = append(evicted, evictedEntry[K, V]{key: key, value: it.value, reason: ReasonCleared})
+	}
+	c.items = make(map[K]item[V])
+	c.expHeap = nil
+	c.expIndex = make(map[K]*expiringItem[K])
+
+	if c.tracker != nil {
+		c.tracker.clear()
+	}
+
+	c.mutex.Unlock()
+
+	c.notifyEvicted(evicted)
+}
+
+// Keys returns all keys currently in the cache, including expired ones
+// that have not yet been swept by the cleanup goroutine.
+func (c *Cache[K, V]) Keys() []K {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]K, 0, len(c.items))
+	for key := range c.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// heapLen returns the number of items currently tracked in the
+// expiration heap. It exists mainly so tests can observe heap size
+// without racing the cleanup goroutine by reaching into expHeap directly.
+func (c *Cache[K, V]) heapLen() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return len(c.expHeap)
+}
+
+// noExpirationPoll is how long the cleanup goroutine sleeps when no
+// item currently has a TTL. It still wakes immediately via wakeCh as
+// soon as a TTL'd item is set.
+const noExpirationPoll = time.Hour
+
+// startCleanup runs the cleanup goroutine. Instead of polling on a
+// fixed tick, it sleeps on a timer reset to the delta until the
+// earliest item in the expiration heap is due, waking early whenever
+// Set pushes a new nearer expiration.
+func (c *Cache[K, V]) startCleanup() {
+	timer := time.NewTimer(c.nextWakeDuration())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			c.deleteExpired()
+			timer.Reset(c.nextWakeDuration())
+		case <-c.wakeCh:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(c.nextWakeDuration())
+		case <-c.stopCleanup:
+			return
+		}
+	}
+}
+
+// nextWakeDuration returns how long the cleanup goroutine should sleep
+// until the earliest TTL'd item expires.
+func (c *Cache[K, V]) nextWakeDuration() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.expHeap) == 0 {
+		return noExpirationPoll
+	}
+
+	delta := time.Until(time.Unix(c.expHeap[0].expiresAt, 0))
+	if delta < 0 {
+		delta = 0
+	}
+	return delta
+}
+
+// wakeCleanup nudges the cleanup goroutine to recompute its sleep
+// duration without blocking if it is already awake.
+func (c *Cache[K, V]) wakeCleanup() {
+	select {
+	case c.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// deleteExpired pops every item at the head of the expiration heap
+// whose TTL has elapsed. Unlike a full-map scan, this only ever
+// touches the items that are actually due.
+func (c *Cache[K, V]) deleteExpired() {
+	now := time.Now().Unix()
+
+	c.mutex.Lock()
+
+	var evicted []evictedEntry[K, V]
+	for len(c.expHeap) > 0 && c.expHeap[0].expiresAt <= now {
+		entry := heap.Pop(&c.expHeap).(*expiringItem[K])
+		delete(c.expIndex, entry.key)
+
+		if it, found := c.items[entry.key]; found {
+			evicted = append(evicted, evictedEntry[K, V]{key: entry.key, value: it.value, reason: ReasonExpired})
+			delete(c.items, entry.key)
+			if c.tracker != nil {
+				c.tracker.remove(entry.key)
+			}
+		}
+	}
+
+	c.mutex.Unlock()
+
+	c.notifyEvicted(evicted)
+}
+
+// Close shuts down the cache cleanup goroutine.
+func (c *Cache[K, V]) Close() {
+	close(c.stopCleanup)
+}
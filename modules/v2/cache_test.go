@@ -0,0 +1,125 @@
+package v2
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+This function tests the basic functionality of setting values and
+retrieving them from the cache without any runtime type assertions.
+*/
+func TestCacheSetAndGet(t *testing.T) {
+	cache := NewCache[string, int]()
+	defer cache.Close()
+
+	cache.Set("int", 42)
+	value, found := cache.Get("int")
+	assert.True(t, found, "Expected to find key 'int'")
+	assert.Equal(t, 42, value, "Expected value 42")
+
+	_, found = cache.Get("non-existent")
+	assert.False(t, found, "Expected not to find key 'non-existent'")
+}
+
+/*
+This function tests that non-string, non-interface key and value types
+work as expected through the generic API.
+*/
+func TestCacheTypedKeysAndValues(t *testing.T) {
+	type point struct{ X, Y int }
+
+	cache := NewCache[int, point]()
+	defer cache.Close()
+
+	cache.Set(1, point{X: 1, Y: 2})
+
+	value, found := cache.Get(1)
+	require.True(t, found, "Expected to find key 1")
+	assert.Equal(t, point{X: 1, Y: 2}, value)
+}
+
+/*
+This function tests that GetOrSet returns the existing value when present
+and otherwise stores and returns the provided value.
+*/
+func TestCacheGetOrSet(t *testing.T) {
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	value, found := cache.GetOrSet("key", "first")
+	assert.False(t, found, "Expected key to be absent on first GetOrSet")
+	assert.Equal(t, "first", value)
+
+	value, found = cache.GetOrSet("key", "second")
+	assert.True(t, found, "Expected key to already exist on second GetOrSet")
+	assert.Equal(t, "first", value, "Expected the original value to be preserved")
+}
+
+/*
+This function tests that Range visits every non-expired item and that
+returning false from the callback stops iteration early.
+*/
+func TestCacheRange(t *testing.T) {
+	cache := NewCache[string, int]()
+	defer cache.Close()
+
+	for i := 0; i < 5; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), i)
+	}
+
+	visited := make(map[string]int)
+	cache.Range(func(key string, value int) bool {
+		visited[key] = value
+		return true
+	})
+	assert.Len(t, visited, 5)
+
+	count := 0
+	cache.Range(func(key string, value int) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count, "Expected Range to stop after the first item")
+}
+
+/*
+This function tests that items with TTL are properly expired and
+removed from the cache after their TTL has elapsed.
+*/
+func TestCacheTTL(t *testing.T) {
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	cache.Set("short-lived", "value", 2*time.Second)
+
+	value, found := cache.Get("short-lived")
+	require.True(t, found, "Expected to find key right after setting it")
+	assert.Equal(t, "value", value)
+
+	time.Sleep(3 * time.Second)
+
+	_, found = cache.Get("short-lived")
+	assert.False(t, found, "Expected item to be gone after TTL expired")
+}
+
+/*
+This function tests that the Keys and Clear methods behave the same way
+as the original interface{}-based cache.
+*/
+func TestCacheKeysAndClear(t *testing.T) {
+	cache := NewCache[string, int]()
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	assert.Len(t, cache.Keys(), 2)
+
+	cache.Clear()
+	assert.Len(t, cache.Keys(), 0)
+}
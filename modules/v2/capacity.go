@@ -0,0 +1,266 @@
+package v2
+
+import "container/list"
+
+// Policy selects which item to evict when a capacity-limited cache is
+// full and a new key needs to be inserted.
+type Policy int
+
+const (
+	// PolicyLRU evicts the least-recently-used item. Both Get and Set
+	// on an existing key count as a use.
+	PolicyLRU Policy = iota
+	// PolicyLFU evicts the least-frequently-used item, breaking ties
+	// by least-recently-used among equally-frequent items.
+	PolicyLFU
+	// PolicyFIFO evicts the oldest inserted item regardless of access
+	// patterns.
+	PolicyFIFO
+)
+
+// WithCapacity limits the cache to at most max items. When a Set would
+// exceed that limit, an item is evicted first according to the
+// configured Policy (PolicyLRU by default).
+func WithCapacity[K comparable, V any](max int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.capacity = max
+	}
+}
+
+// WithPolicy selects the eviction policy used once WithCapacity is set.
+func WithPolicy[K comparable, V any](policy Policy) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.policy = policy
+	}
+}
+
+// SkipTTLExtensionOnHit decouples LRU/LFU touch tracking from TTL
+// renewal. By default, a capacity-limited cache renews an item's TTL
+// whenever it is touched by Get. Passing true keeps the touch tracking
+// (so the item is still protected from capacity eviction) without
+// renewing its TTL, giving DNS-style fixed expiration despite reads.
+func SkipTTLExtensionOnHit[K comparable, V any](skip bool) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.skipTTLExtensionOnHit = skip
+	}
+}
+
+// capacityTracker maintains whatever bookkeeping a Policy needs to pick
+// an eviction candidate. Every key currently in the cache's
+// items map has a corresponding tracker entry while capacity limiting
+// is enabled.
+type capacityTracker[K comparable] interface {
+	add(key K)
+	touch(key K)
+	remove(key K)
+	evictCandidate() (K, bool)
+	clear()
+}
+
+func newCapacityTracker[K comparable](policy Policy) capacityTracker[K] {
+	switch policy {
+	case PolicyLFU:
+		return newLFUTracker[K]()
+	case PolicyFIFO:
+		return newFIFOTracker[K]()
+	default:
+		return newLRUTracker[K]()
+	}
+}
+
+// lruTracker evicts the least-recently-used key, tracked with a
+// doubly-linked list kept in most-to-least-recently-used order.
+type lruTracker[K comparable] struct {
+	order    *list.List
+	elements map[K]*list.Element
+}
+
+func newLRUTracker[K comparable]() *lruTracker[K] {
+	return &lruTracker[K]{order: list.New(), elements: make(map[K]*list.Element)}
+}
+
+func (t *lruTracker[K]) add(key K) {
+	t.elements[key] = t.order.PushFront(key)
+}
+
+func (t *lruTracker[K]) touch(key K) {
+	if el, ok := t.elements[key]; ok {
+		t.order.MoveToFront(el)
+	}
+}
+
+func (t *lruTracker[K]) remove(key K) {
+	if el, ok := t.elements[key]; ok {
+		t.order.Remove(el)
+		delete(t.elements, key)
+	}
+}
+
+func (t *lruTracker[K]) evictCandidate() (K, bool) {
+	back := t.order.Back()
+	if back == nil {
+		var zero K
+		return zero, false
+	}
+	key := back.Value.(K)
+	t.order.Remove(back)
+	delete(t.elements, key)
+	return key, true
+}
+
+func (t *lruTracker[K]) clear() {
+	t.order.Init()
+	t.elements = make(map[K]*list.Element)
+}
+
+// fifoTracker evicts the oldest inserted key. Unlike lruTracker, touch
+// never reorders the list.
+type fifoTracker[K comparable] struct {
+	order    *list.List
+	elements map[K]*list.Element
+}
+
+func newFIFOTracker[K comparable]() *fifoTracker[K] {
+	return &fifoTracker[K]{order: list.New(), elements: make(map[K]*list.Element)}
+}
+
+func (t *fifoTracker[K]) add(key K) {
+	t.elements[key] = t.order.PushBack(key)
+}
+
+func (t *fifoTracker[K]) touch(key K) {
+	// Insertion order is fixed: reads and re-sets never move a key.
+}
+
+func (t *fifoTracker[K]) remove(key K) {
+	if el, ok := t.elements[key]; ok {
+		t.order.Remove(el)
+		delete(t.elements, key)
+	}
+}
+
+func (t *fifoTracker[K]) evictCandidate() (K, bool) {
+	front := t.order.Front()
+	if front == nil {
+		var zero K
+		return zero, false
+	}
+	key := front.Value.(K)
+	t.order.Remove(front)
+	delete(t.elements, key)
+	return key, true
+}
+
+func (t *fifoTracker[K]) clear() {
+	t.order.Init()
+	t.elements = make(map[K]*list.Element)
+}
+
+// lfuTracker evicts the least-frequently-used key using the classic
+// O(1) LFU scheme: a frequency counter per key plus a bucket (list) of
+// keys for each observed frequency, with a pointer to the bucket
+// holding the current minimum frequency. Ties within a bucket are
+// broken least-recently-used first. When remove or an eviction leaves
+// the minimum-frequency bucket empty, the next evictCandidate scans the
+// remaining buckets to find the new minimum.
+type lfuTracker[K comparable] struct {
+	freq     map[K]int
+	buckets  map[int]*list.List
+	elements map[K]*list.Element
+	minFreq  int
+}
+
+func newLFUTracker[K comparable]() *lfuTracker[K] {
+	return &lfuTracker[K]{
+		freq:     make(map[K]int),
+		buckets:  make(map[int]*list.List),
+		elements: make(map[K]*list.Element),
+	}
+}
+
+func (t *lfuTracker[K]) add(key K) {
+	t.freq[key] = 1
+	if t.buckets[1] == nil {
+		t.buckets[1] = list.New()
+	}
+	t.elements[key] = t.buckets[1].PushFront(key)
+	t.minFreq = 1
+}
+
+func (t *lfuTracker[K]) touch(key K) {
+	el, ok := t.elements[key]
+	if !ok {
+		return
+	}
+
+	f := t.freq[key]
+	t.buckets[f].Remove(el)
+	if t.buckets[f].Len() == 0 {
+		delete(t.buckets, f)
+		if t.minFreq == f {
+			t.minFreq = f + 1
+		}
+	}
+
+	nf := f + 1
+	t.freq[key] = nf
+	if t.buckets[nf] == nil {
+		t.buckets[nf] = list.New()
+	}
+	t.elements[key] = t.buckets[nf].PushFront(key)
+}
+
+func (t *lfuTracker[K]) remove(key K) {
+	el, ok := t.elements[key]
+	if !ok {
+		return
+	}
+
+	f := t.freq[key]
+	t.buckets[f].Remove(el)
+	if t.buckets[f].Len() == 0 {
+		delete(t.buckets, f)
+	}
+	delete(t.freq, key)
+	delete(t.elements, key)
+}
+
+func (t *lfuTracker[K]) evictCandidate() (K, bool) {
+	bucket, ok := t.buckets[t.minFreq]
+	if !ok {
+		min := -1
+		for f := range t.buckets {
+			if min == -1 || f < min {
+				min = f
+			}
+		}
+		if min == -1 {
+			var zero K
+			return zero, false
+		}
+		t.minFreq = min
+		bucket = t.buckets[min]
+	}
+
+	back := bucket.Back()
+	if back == nil {
+		var zero K
+		return zero, false
+	}
+
+	key := back.Value.(K)
+	bucket.Remove(back)
+	if bucket.Len() == 0 {
+		delete(t.buckets, t.minFreq)
+	}
+	delete(t.freq, key)
+	delete(t.elements, key)
+	return key, true
+}
+
+func (t *lfuTracker[K]) clear() {
+	t.freq = make(map[K]int)
+	t.buckets = make(map[int]*list.List)
+	t.elements = make(map[K]*list.Element)
+	t.minFreq = 0
+}
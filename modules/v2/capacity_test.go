@@ -0,0 +1,142 @@
+package v2
+
+import (
+	"testing"
+	"time"
+)
+
+/*
+This function tests that a PolicyLRU cache evicts the least-recently
+used key once capacity is exceeded, and that a Get on a key protects it
+from eviction.
+*/
+func TestCacheCapacityLRU(t *testing.T) {
+	cache := NewCache[string, int](WithCapacity[string, int](2), WithPolicy[string, int](PolicyLRU))
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// Touch "a" so it becomes more recently used than "b".
+	cache.Get("a")
+
+	cache.Set("c", 3)
+
+	if _, found := cache.Get("b"); found {
+		t.Error("Expected 'b' to be evicted as the least-recently-used key")
+	}
+	if _, found := cache.Get("a"); !found {
+		t.Error("Expected 'a' to survive eviction after being touched")
+	}
+	if _, found := cache.Get("c"); !found {
+		t.Error("Expected 'c' to be present after insertion")
+	}
+}
+
+/*
+This function tests that a PolicyFIFO cache evicts strictly in
+insertion order regardless of reads.
+*/
+func TestCacheCapacityFIFO(t *testing.T) {
+	cache := NewCache[string, int](WithCapacity[string, int](2), WithPolicy[string, int](PolicyFIFO))
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// Reading "a" must not protect it under FIFO.
+	cache.Get("a")
+
+	cache.Set("c", 3)
+
+	if _, found := cache.Get("a"); found {
+		t.Error("Expected 'a' to be evicted as the oldest key under FIFO")
+	}
+	if _, found := cache.Get("b"); !found {
+		t.Error("Expected 'b' to survive")
+	}
+}
+
+/*
+This function tests that a PolicyLFU cache evicts the least-frequently
+used key, preferring to keep keys that have been accessed more often.
+*/
+func TestCacheCapacityLFU(t *testing.T) {
+	cache := NewCache[string, int](WithCapacity[string, int](2), WithPolicy[string, int](PolicyLFU))
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	cache.Get("a")
+	cache.Get("a")
+
+	cache.Set("c", 3)
+
+	if _, found := cache.Get("b"); found {
+		t.Error("Expected 'b' to be evicted as the least-frequently-used key")
+	}
+	if _, found := cache.Get("a"); !found {
+		t.Error("Expected 'a' to survive due to higher access frequency")
+	}
+}
+
+/*
+This function tests that eviction caused by a capacity limit fires the
+OnEvicted callback with ReasonCapacity.
+*/
+func TestCacheCapacityEvictionFiresCallback(t *testing.T) {
+	cache := NewCache[string, int](WithCapacity[string, int](1))
+	defer cache.Close()
+
+	var lastReason EvictionReason
+	var lastKey string
+	cache.OnEvicted(func(key string, value int, reason EvictionReason) {
+		lastKey, lastReason = key, reason
+	})
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	if lastKey != "a" || lastReason != ReasonCapacity {
+		t.Errorf("Expected eviction of 'a' with ReasonCapacity, got key=%s reason=%v", lastKey, lastReason)
+	}
+}
+
+/*
+This function tests that, by default, touching a capacity-tracked item
+renews its TTL, and that SkipTTLExtensionOnHit(true) disables that
+renewal.
+*/
+func TestCacheSkipTTLExtensionOnHit(t *testing.T) {
+	t.Run("renews by default", func(t *testing.T) {
+		cache := NewCache[string, string](WithCapacity[string, string](10))
+		defer cache.Close()
+
+		cache.Set("key", "value", 5*time.Second)
+		time.Sleep(1 * time.Second)
+		cache.Get("key") // renew, pushing expiration out another 5s
+		time.Sleep(4 * time.Second)
+
+		if _, found := cache.Get("key"); !found {
+			t.Error("Expected TTL to have been renewed by the earlier Get")
+		}
+	})
+
+	t.Run("skips when configured", func(t *testing.T) {
+		cache := NewCache[string, string](
+			WithCapacity[string, string](10),
+			SkipTTLExtensionOnHit[string, string](true),
+		)
+		defer cache.Close()
+
+		cache.Set("key", "value", 2*time.Second)
+		time.Sleep(1 * time.Second)
+		cache.Get("key") // touched, but TTL must not be pushed out
+		time.Sleep(1500 * time.Millisecond)
+
+		if _, found := cache.Get("key"); found {
+			t.Error("Expected TTL to expire on schedule with SkipTTLExtensionOnHit(true)")
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package v2
+
+import "sync"
+
+// EvictionReason describes why an item left the cache.
+type EvictionReason int
+
+const (
+	// ReasonExpired means the item's TTL elapsed.
+	ReasonExpired EvictionReason = iota
+	// ReasonDeleted means the item was removed via Delete.
+	ReasonDeleted
+	// ReasonCleared means the item was removed via Clear.
+	ReasonCleared
+	// ReasonReplaced means the item was overwritten by a new Set call.
+	ReasonReplaced
+	// ReasonCapacity means the item was evicted to make room under a
+	// capacity-limited eviction policy.
+	ReasonCapacity
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonExpired:
+		return "expired"
+	case ReasonDeleted:
+		return "deleted"
+	case ReasonCleared:
+		return "cleared"
+	case ReasonReplaced:
+		return "replaced"
+	case ReasonCapacity:
+		return "capacity"
+	default:
+		return "unknown"
+	}
+}
+
+// evictedEntry records an item that left the cache so its eviction
+// callback can be invoked after the cache mutex has been released.
+type evictedEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// evictionCallback holds the user-registered OnEvicted hook behind its
+// own mutex so registering it never contends with the main cache lock.
+type evictionCallback[K comparable, V any] struct {
+	mutex sync.Mutex
+	fn    func(key K, value V, reason EvictionReason)
+}
+
+// OnEvicted registers fn to be called whenever an item leaves the
+// cache, whether through expiration, Delete, Clear, a replacing Set, or
+// capacity-limited eviction. fn is invoked after the cache mutex has
+// been released, so it is safe to call back into the cache from it.
+func (c *Cache[K, V]) OnEvicted(fn func(key K, value V, reason EvictionReason)) {
+	c.onEvicted.mutex.Lock()
+	defer c.onEvicted.mutex.Unlock()
+	c.onEvicted.fn = fn
+}
+
+// notifyEvicted invokes the registered OnEvicted callback, if any, for
+// every entry collected while the cache mutex was held. It must be
+// called after that mutex has been released.
+func (c *Cache[K, V]) notifyEvicted(entries []evictedEntry[K, V]) {
+	if len(entries) == 0 {
+		return
+	}
+
+	c.onEvicted.mutex.Lock()
+	fn := c.onEvicted.fn
+	c.onEvicted.mutex.Unlock()
+
+	if fn == nil {
+		return
+	}
+
+	for _, entry := range entries {
+		fn(entry.key, entry.value, entry.reason)
+	}
+}
@@ -0,0 +1,41 @@
+package v2
+
+// expiringItem tracks a single key's place in the expiration heap.
+// index is maintained by container/heap so the entry can be located and
+// removed or re-prioritized in O(log N) without a linear scan.
+type expiringItem[K comparable] struct {
+	key       K
+	expiresAt int64
+	index     int
+}
+
+// expirationHeap is a min-heap of expiringItem ordered by expiresAt,
+// letting the cleanup goroutine find the next item to expire in O(1)
+// and wake only when it is actually due.
+type expirationHeap[K comparable] []*expiringItem[K]
+
+func (h expirationHeap[K]) Len() int { return len(h) }
+
+func (h expirationHeap[K]) Less(i, j int) bool { return h[i].expiresAt < h[j].expiresAt }
+
+func (h expirationHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expirationHeap[K]) Push(x any) {
+	entry := x.(*expiringItem[K])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *expirationHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+	return entry
+}
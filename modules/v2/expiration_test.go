@@ -0,0 +1,84 @@
+package v2
+
+import (
+	"testing"
+	"time"
+)
+
+/*
+This function tests that the heap-based cleanup goroutine expires an
+item close to its TTL instead of waiting for a fixed poll interval,
+and that a later-set, nearer-expiring item still wakes it up on time.
+It asserts this via OnEvicted rather than Get, since Get lazily expires
+items on access regardless of whether the background timer ever fires.
+*/
+func TestCacheHeapExpirationWakesEarly(t *testing.T) {
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	fired := make(chan string, 1)
+	cache.OnEvicted(func(key string, value string, reason EvictionReason) {
+		if reason == ReasonExpired {
+			fired <- key
+		}
+	})
+
+	// A long-lived item establishes a far-future heap head...
+	cache.Set("far", "value", time.Hour)
+
+	// ...then a short-lived item is set after it. The cleanup timer
+	// must wake for this one long before the hour is up.
+	cache.Set("near", "value", 500*time.Millisecond)
+
+	select {
+	case key := <-fired:
+		if key != "near" {
+			t.Errorf("Expected 'near' to be reaped first, got %q", key)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Expected 'near' to be expired by the background cleanup goroutine")
+	}
+}
+
+/*
+This function tests that items without a TTL never enter the
+expiration heap and are left untouched by cleanup.
+*/
+func TestCachePermanentItemsNeverExpire(t *testing.T) {
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	cache.Set("permanent", "value")
+
+	if n := cache.heapLen(); n != 0 {
+		t.Errorf("Expected no items in the expiration heap, got %d", n)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	if _, found := cache.Get("permanent"); !found {
+		t.Error("Expected permanent item to remain in the cache")
+	}
+}
+
+/*
+This function tests that re-setting a key with a new TTL reprioritizes
+its existing heap entry instead of leaving a stale one behind.
+*/
+func TestCacheResettingTTLUpdatesHeap(t *testing.T) {
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	cache.Set("key", "v1", time.Hour)
+	cache.Set("key", "v2", 500*time.Millisecond)
+
+	if n := cache.heapLen(); n != 1 {
+		t.Errorf("Expected exactly one heap entry for 'key', got %d", n)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	if _, found := cache.Get("key"); found {
+		t.Error("Expected 'key' to expire according to its updated TTL")
+	}
+}
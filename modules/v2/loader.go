@@ -0,0 +1,85 @@
+package v2
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoLoader is returned by GetOrLoad when the key is missing or
+// expired and no loader function has been configured via SetLoaderFunc.
+var ErrNoLoader = errors.New("v2: no loader function configured")
+
+// loaderFunc loads the value for a missing/expired key, along with the
+// TTL it should be cached for (0 meaning no expiration).
+type loaderFunc[K comparable, V any] func(key K) (V, time.Duration, error)
+
+// call tracks a single in-flight load so concurrent callers for the
+// same key can wait on and share its result instead of each triggering
+// their own load (groupcache-style single-flight).
+type call[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	err   error
+}
+
+// SetLoaderFunc configures the function used to populate the cache on
+// a GetOrLoad miss.
+func (c *Cache[K, V]) SetLoaderFunc(fn func(key K) (V, time.Duration, error)) {
+	c.loaderMu.Lock()
+	defer c.loaderMu.Unlock()
+	c.loader = fn
+}
+
+// GetOrLoad returns the cached value for key if present and not
+// expired. Otherwise it invokes the configured loader exactly once,
+// even if multiple goroutines call GetOrLoad for the same missing key
+// concurrently, and caches the result with the TTL the loader returns.
+func (c *Cache[K, V]) GetOrLoad(key K) (V, error) {
+	if value, found := c.Get(key); found {
+		return value, nil
+	}
+
+	c.loaderMu.Lock()
+	if inflight, ok := c.inflight[key]; ok {
+		c.loaderMu.Unlock()
+		inflight.wg.Wait()
+		return inflight.value, inflight.err
+	}
+
+	// A load that finished between the Get above and taking loaderMu
+	// has already cached its value and removed its inflight entry.
+	if value, found := c.Get(key); found {
+		c.loaderMu.Unlock()
+		return value, nil
+	}
+
+	loader := c.loader
+	if loader == nil {
+		c.loaderMu.Unlock()
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	inflight := &call[V]{}
+	inflight.wg.Add(1)
+	if c.inflight == nil {
+		c.inflight = make(map[K]*call[V])
+	}
+	c.inflight[key] = inflight
+	c.loaderMu.Unlock()
+
+	value, ttl, err := loader(key)
+	if err == nil {
+		c.Set(key, value, ttl)
+	}
+	inflight.value, inflight.err = value, err
+
+	c.loaderMu.Lock()
+	delete(c.inflight, key)
+	c.loaderMu.Unlock()
+
+	inflight.wg.Done()
+
+	return value, err
+}
@@ -0,0 +1,116 @@
+package v2
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+)
+
+// snapshotEntry is the gob-serializable form of one cache item.
+// Expiration is stored as an absolute Unix timestamp, re-validated on
+// Load so already-expired entries are dropped rather than resurrected.
+type snapshotEntry[K comparable, V any] struct {
+	Key        K
+	Value      V
+	Expiration int64
+	TTL        time.Duration
+}
+
+// Save serializes the cache's current contents to w using
+// encoding/gob, preserving keys, values, and remaining TTLs as absolute
+// Unix expirations. Since V is often interface{}, callers must
+// gob.Register their concrete value types before calling Save or Load.
+func (c *Cache[K, V]) Save(w io.Writer) error {
+	c.mutex.RLock()
+	entries := make([]snapshotEntry[K, V], 0, len(c.items))
+	for key, it := range c.items {
+		entries = append(entries, snapshotEntry[K, V]{
+			Key:        key,
+			Value:      it.value,
+			Expiration: it.expiration,
+			TTL:        it.ttl,
+		})
+	}
+	c.mutex.RUnlock()
+
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+// Load replaces the cache's contents with a snapshot previously written
+// by Save. Entries whose absolute expiration has already passed are
+// dropped instead of being loaded.
+func (c *Cache[K, V]) Load(r io.Reader) error {
+	var entries []snapshotEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+
+	c.mutex.Lock()
+
+	c.items = make(map[K]item[V], len(entries))
+	c.expHeap = nil
+	c.expIndex = make(map[K]*expiringItem[K])
+	if c.tracker != nil {
+		c.tracker.clear()
+	}
+
+	wake := false
+	for _, entry := range entries {
+		if entry.Expiration > 0 && now >= entry.Expiration {
+			continue
+		}
+
+		c.items[entry.Key] = item[V]{
+			value:      entry.Value,
+			expiration: entry.Expiration,
+			ttl:        entry.TTL,
+		}
+		if c.updateExpirationLocked(entry.Key, entry.Expiration) {
+			wake = true
+		}
+		if c.tracker != nil {
+			c.tracker.add(entry.Key)
+		}
+	}
+
+	var evicted []evictedEntry[K, V]
+	if c.tracker != nil {
+		evicted = c.evictForCapacityLocked()
+	}
+
+	c.mutex.Unlock()
+
+	if wake {
+		c.wakeCleanup()
+	}
+	c.notifyEvicted(evicted)
+
+	return nil
+}
+
+// SaveFile serializes the cache to the file at path, creating it if
+// necessary and truncating it otherwise.
+func (c *Cache[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Save(f)
+}
+
+// LoadFile replaces the cache's contents with a snapshot read from the
+// file at path.
+func (c *Cache[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
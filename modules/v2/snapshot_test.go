@@ -0,0 +1,141 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/gob"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/*
+This function tests that Save followed by Load round-trips keys,
+values, and remaining TTLs through an in-memory buffer.
+*/
+func TestCacheSaveLoadRoundTrip(t *testing.T) {
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	cache.Set("permanent", "value1")
+	cache.Set("expiring", "value2", time.Hour)
+
+	var buf bytes.Buffer
+	if err := cache.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewCache[string, string]()
+	defer restored.Close()
+
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	value, found := restored.Get("permanent")
+	if !found || value != "value1" {
+		t.Errorf("Expected 'permanent' to restore to 'value1', got %v, found=%v", value, found)
+	}
+
+	value, found = restored.Get("expiring")
+	if !found || value != "value2" {
+		t.Errorf("Expected 'expiring' to restore to 'value2', got %v, found=%v", value, found)
+	}
+}
+
+/*
+This function tests that entries already expired at save time are
+dropped on Load instead of being resurrected. The expired entry is
+encoded directly to bypass the source cache's own expiration handling,
+simulating a snapshot taken just before the process exited.
+*/
+func TestCacheLoadDropsAlreadyExpiredEntries(t *testing.T) {
+	entries := []snapshotEntry[string, string]{
+		{Key: "short-lived", Value: "value", Expiration: time.Now().Add(-time.Second).Unix()},
+		{Key: "permanent", Value: "value"},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entries); err != nil {
+		t.Fatalf("failed to encode snapshot entries: %v", err)
+	}
+
+	loaded := NewCache[string, string]()
+	defer loaded.Close()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if _, found := loaded.Get("short-lived"); found {
+		t.Error("Expected already-expired entry to be dropped on Load")
+	}
+	if _, found := loaded.Get("permanent"); !found {
+		t.Error("Expected non-expired entry to survive Load")
+	}
+}
+
+/*
+This function tests that Load wakes the background cleanup goroutine
+when a loaded entry is about to expire, rather than leaving it to be
+lazily expired on the next Get. The destination cache starts with an
+empty heap (so its cleanup goroutine is parked on noExpirationPoll),
+and OnEvicted is used to observe the background reap directly.
+*/
+func TestCacheLoadWakesCleanupForNearExpiryEntry(t *testing.T) {
+	entries := []snapshotEntry[string, string]{
+		{Key: "soon", Value: "value", Expiration: time.Now().Add(1500 * time.Millisecond).Unix(), TTL: 2 * time.Second},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entries); err != nil {
+		t.Fatalf("failed to encode snapshot entries: %v", err)
+	}
+
+	cache := NewCache[string, string]()
+	defer cache.Close()
+
+	fired := make(chan EvictionReason, 1)
+	cache.OnEvicted(func(key string, value string, reason EvictionReason) {
+		fired <- reason
+	})
+
+	if err := cache.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	select {
+	case reason := <-fired:
+		if reason != ReasonExpired {
+			t.Errorf("Expected ReasonExpired, got %v", reason)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected background cleanup to reap the loaded entry promptly")
+	}
+}
+
+/*
+This function tests that SaveFile and LoadFile round-trip through an
+actual file on disk.
+*/
+func TestCacheSaveLoadFile(t *testing.T) {
+	cache := NewCache[string, int]()
+	defer cache.Close()
+
+	cache.Set("count", 42)
+
+	path := filepath.Join(t.TempDir(), "cache.gob")
+	if err := cache.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	restored := NewCache[string, int]()
+	defer restored.Close()
+
+	if err := restored.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	value, found := restored.Get("count")
+	if !found || value != 42 {
+		t.Errorf("Expected 'count' to restore to 42, got %v, found=%v", value, found)
+	}
+}